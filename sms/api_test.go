@@ -0,0 +1,254 @@
+package sms_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/amirhosseinab/go-sms-ir/sms"
+)
+
+func TestBulkSMS_SendShouldUseAppropriateURLAndHeaders(t *testing.T) {
+	fakeToken := "fake_token"
+	gotToken := ""
+	gotContentType := ""
+	gotPath := ""
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotToken = r.Header.Get("x-sms-ir-secure-token")
+		gotContentType = r.Header.Get("Content-Type")
+		gotPath = r.URL.Path
+		_ = json.NewEncoder(w).Encode(struct {
+			MessageIds   []int64 `json:"MessageIds"`
+			IsSuccessful bool    `json:"IsSuccessful"`
+		}{MessageIds: []int64{1, 2}, IsSuccessful: true})
+	}))
+	defer ts.Close()
+
+	c := sms.NewBulkSMSClient(createFakeToken(fakeToken), ts.URL)
+	ids, err := c.Send("3000xxx", []string{"hi"}, []string{"0912", "0913"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotToken != fakeToken {
+		t.Errorf("expected token '%s', got '%s'", fakeToken, gotToken)
+	}
+	if gotContentType != "application/json" {
+		t.Errorf("expected '%s', got '%s'", "application/json", gotContentType)
+	}
+	if strings.ToLower(gotPath) != "/send/bulk" {
+		t.Errorf("expected '%s', got '%s'", "/Send/Bulk", gotPath)
+	}
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Errorf("expected [1 2], got %v", ids)
+	}
+}
+
+func TestBulkSMS_SendShouldReturnErrorWhenUnsuccessful(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(struct {
+			MessageIds   []int64 `json:"MessageIds"`
+			IsSuccessful bool    `json:"IsSuccessful"`
+		}{IsSuccessful: false})
+	}))
+	defer ts.Close()
+
+	c := sms.NewBulkSMSClient(createFakeToken(""), ts.URL)
+	if _, err := c.Send("3000xxx", []string{"hi"}, []string{"0912"}, nil); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestBulkSMS_GetStatusByBatchKeyShouldUseAppropriateURL(t *testing.T) {
+	gotQuery := ""
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.Query().Get("BatchKey")
+		_ = json.NewEncoder(w).Encode(struct {
+			Statuses     []sms.MessageStatus `json:"Statuses"`
+			IsSuccessful bool                `json:"IsSuccessful"`
+		}{Statuses: []sms.MessageStatus{{MessageId: 1, Status: 1}}, IsSuccessful: true})
+	}))
+	defer ts.Close()
+
+	c := sms.NewBulkSMSClient(createFakeToken(""), ts.URL)
+	statuses, err := c.GetStatusByBatchKey(42)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotQuery != "42" {
+		t.Errorf("expected BatchKey '42', got '%s'", gotQuery)
+	}
+	if len(statuses) != 1 || statuses[0].MessageId != 1 {
+		t.Errorf("unexpected statuses: %v", statuses)
+	}
+}
+
+func TestBulkSMS_GetStatusByMessageIdShouldReturnErrorWhenNotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(struct {
+			IsSuccessful bool `json:"IsSuccessful"`
+		}{IsSuccessful: false})
+	}))
+	defer ts.Close()
+
+	c := sms.NewBulkSMSClient(createFakeToken(""), ts.URL)
+	if _, err := c.GetStatusByMessageId(1); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestBulkSMS_GetReceivedMessagesShouldUseAppropriateQuery(t *testing.T) {
+	var gotPage, gotDate string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPage = r.URL.Query().Get("PageNumber")
+		gotDate = r.URL.Query().Get("ShamsiDate")
+		_ = json.NewEncoder(w).Encode(struct {
+			Messages     []sms.ReceivedMessage `json:"Messages"`
+			IsSuccessful bool                  `json:"IsSuccessful"`
+		}{IsSuccessful: true})
+	}))
+	defer ts.Close()
+
+	c := sms.NewBulkSMSClient(createFakeToken(""), ts.URL)
+	if _, err := c.GetReceivedMessages(2, "1403/05/01"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotPage != "2" {
+		t.Errorf("expected PageNumber '2', got '%s'", gotPage)
+	}
+	if gotDate != "1403/05/01" {
+		t.Errorf("expected ShamsiDate '1403/05/01', got '%s'", gotDate)
+	}
+}
+
+func TestBulkSMS_UltraFastSendShouldSendsRequestBody(t *testing.T) {
+	type data struct {
+		Mobile         string `json:"Mobile"`
+		TemplateId     int    `json:"TemplateId"`
+		ParameterArray []struct {
+			Parameter      string `json:"Parameter"`
+			ParameterValue string `json:"ParameterValue"`
+		} `json:"ParameterArray"`
+	}
+	d := data{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&d)
+		_ = json.NewEncoder(w).Encode(struct {
+			MessageId    float64 `json:"MessageId"`
+			IsSuccessful bool    `json:"IsSuccessful"`
+		}{MessageId: 7, IsSuccessful: true})
+	}))
+	defer ts.Close()
+
+	c := sms.NewBulkSMSClient(createFakeToken(""), ts.URL)
+	id, err := c.UltraFastSend(123, map[string]string{"param1": "value1"}, "fake_mobile")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != "7" {
+		t.Errorf("expected id '7', got '%s'", id)
+	}
+	if d.Mobile != "fake_mobile" || d.TemplateId != 123 {
+		t.Errorf("unexpected request body: %+v", d)
+	}
+	if len(d.ParameterArray) != 1 || d.ParameterArray[0].Parameter != "param1" {
+		t.Errorf("unexpected parameter array: %+v", d.ParameterArray)
+	}
+}
+
+func TestBulkSMS_ManagedContactCRUD(t *testing.T) {
+	var lastMethod, lastPath string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastMethod = r.Method
+		lastPath = r.URL.Path
+		switch r.Method {
+		case http.MethodPost:
+			_ = json.NewEncoder(w).Encode(struct {
+				Id           int64 `json:"Id"`
+				IsSuccessful bool  `json:"IsSuccessful"`
+			}{Id: 10, IsSuccessful: true})
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(struct {
+				Contacts     []sms.ManagedContact `json:"Contacts"`
+				IsSuccessful bool                 `json:"IsSuccessful"`
+			}{Contacts: []sms.ManagedContact{{Id: 10, Mobile: "0912"}}, IsSuccessful: true})
+		default:
+			_ = json.NewEncoder(w).Encode(struct {
+				IsSuccessful bool `json:"IsSuccessful"`
+			}{IsSuccessful: true})
+		}
+	}))
+	defer ts.Close()
+
+	c := sms.NewBulkSMSClient(createFakeToken(""), ts.URL)
+
+	id, err := c.CreateManagedContact(sms.ManagedContact{Mobile: "0912"})
+	if err != nil || id != 10 {
+		t.Fatalf("expected (10, nil), got (%d, %v)", id, err)
+	}
+	if lastPath != "/ManagedContact" {
+		t.Errorf("expected path '/ManagedContact', got '%s'", lastPath)
+	}
+
+	contacts, err := c.GetManagedContacts(1)
+	if err != nil || len(contacts) != 1 {
+		t.Fatalf("unexpected result: %v, %v", contacts, err)
+	}
+
+	if err := c.UpdateManagedContact(sms.ManagedContact{Id: 10, Mobile: "0913"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lastMethod != http.MethodPut {
+		t.Errorf("expected PUT, got '%s'", lastMethod)
+	}
+
+	if err := c.DeleteManagedContact(10); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lastMethod != http.MethodDelete {
+		t.Errorf("expected DELETE, got '%s'", lastMethod)
+	}
+}
+
+func TestBulkSMS_ManagedContactCategoryCRUD(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			_ = json.NewEncoder(w).Encode(struct {
+				Id           int64 `json:"Id"`
+				IsSuccessful bool  `json:"IsSuccessful"`
+			}{Id: 5, IsSuccessful: true})
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(struct {
+				Categories   []sms.ManagedContactCategory `json:"Categories"`
+				IsSuccessful bool                         `json:"IsSuccessful"`
+			}{Categories: []sms.ManagedContactCategory{{Id: 5, Name: "VIP"}}, IsSuccessful: true})
+		default:
+			_ = json.NewEncoder(w).Encode(struct {
+				IsSuccessful bool `json:"IsSuccessful"`
+			}{IsSuccessful: true})
+		}
+	}))
+	defer ts.Close()
+
+	c := sms.NewBulkSMSClient(createFakeToken(""), ts.URL)
+
+	id, err := c.CreateManagedContactCategory("VIP")
+	if err != nil || id != 5 {
+		t.Fatalf("expected (5, nil), got (%d, %v)", id, err)
+	}
+
+	categories, err := c.GetManagedContactCategories()
+	if err != nil || len(categories) != 1 || categories[0].Name != "VIP" {
+		t.Fatalf("unexpected result: %v, %v", categories, err)
+	}
+
+	if err := c.UpdateManagedContactCategory(sms.ManagedContactCategory{Id: 5, Name: "VIP2"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := c.DeleteManagedContactCategory(5); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,144 @@
+package sms
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// BulkSMSClient talks to the SMS.ir bulk messaging REST API.
+type BulkSMSClient struct {
+	token   *Token
+	baseURL string
+
+	httpClient  *http.Client
+	retryPolicy RetryPolicy
+	timeout     time.Duration
+}
+
+// NewBulkSMSClient creates a BulkSMSClient that authenticates every request
+// with the given Token and talks to baseURL. Options configure the HTTP
+// transport, retry behavior, and per-request timeout.
+func NewBulkSMSClient(token *Token, baseURL string, opts ...ClientOption) *BulkSMSClient {
+	c := &BulkSMSClient{
+		token:   token,
+		baseURL: baseURL,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *BulkSMSClient) do(ctx context.Context, method, path, token string, body, out interface{}) (requestResult, error) {
+	return doJSONRequest(ctx, c.httpClient, c.retryPolicy, c.timeout, method, c.baseURL+path, token, body, out)
+}
+
+func (c *BulkSMSClient) token0(ctx context.Context) (string, error) {
+	return c.token.GetContext(ctx)
+}
+
+// GetCredit returns the remaining SMS credit for the account. It is
+// equivalent to GetCreditContext(context.Background()).
+func (c *BulkSMSClient) GetCredit() (int, error) {
+	return c.GetCreditContext(context.Background())
+}
+
+// GetCreditContext is like GetCredit but carries ctx through the request.
+func (c *BulkSMSClient) GetCreditContext(ctx context.Context) (int, error) {
+	token, err := c.token0(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var resp struct {
+		apiEnvelope
+		Credit int `json:"Credit"`
+	}
+	result, err := c.do(ctx, http.MethodGet, "/Credit", token, nil, &resp)
+	if err != nil {
+		return 0, err
+	}
+	if !resp.IsSuccessful {
+		return resp.Credit, resp.err("GetCredit", ErrInvalidToken, result)
+	}
+	return resp.Credit, nil
+}
+
+// SendVerificationCode sends a one-time verification code to mobile and
+// returns the resulting verification code id. It is equivalent to
+// SendVerificationCodeContext(context.Background(), mobile, code).
+func (c *BulkSMSClient) SendVerificationCode(mobile, code string) (string, error) {
+	return c.SendVerificationCodeContext(context.Background(), mobile, code)
+}
+
+// SendVerificationCodeContext is like SendVerificationCode but carries ctx
+// through the request.
+func (c *BulkSMSClient) SendVerificationCodeContext(ctx context.Context, mobile, code string) (string, error) {
+	token, err := c.token0(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	reqBody := struct {
+		MobileNumber string `json:"MobileNumber"`
+		Code         string `json:"Code"`
+	}{MobileNumber: mobile, Code: code}
+
+	var resp struct {
+		apiEnvelope
+		VerificationCodeId float64 `json:"VerificationCodeId"`
+	}
+	result, err := c.do(ctx, http.MethodPost, "/VerificationCode", token, reqBody, &resp)
+	if err != nil {
+		return "", err
+	}
+	id := formatNumericId(resp.VerificationCodeId)
+	if !resp.IsSuccessful {
+		return id, resp.err("SendVerificationCode", ErrInvalidMobile, result)
+	}
+	return id, nil
+}
+
+// SendByTemplate sends a templated SMS to mobile, substituting params into
+// the template identified by templateId. It is equivalent to
+// SendByTemplateContext(context.Background(), mobile, templateId, params).
+func (c *BulkSMSClient) SendByTemplate(mobile string, templateId int, params map[string]string) (string, error) {
+	return c.SendByTemplateContext(context.Background(), mobile, templateId, params)
+}
+
+// SendByTemplateContext is like SendByTemplate but carries ctx through the
+// request.
+func (c *BulkSMSClient) SendByTemplateContext(ctx context.Context, mobile string, templateId int, params map[string]string) (string, error) {
+	token, err := c.token0(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	type parameter struct {
+		Parameter      string `json:"Parameter"`
+		ParameterValue string `json:"ParameterValue"`
+	}
+	reqBody := struct {
+		Mobile         string      `json:"Mobile"`
+		TemplateId     int         `json:"TemplateId"`
+		ParameterArray []parameter `json:"ParameterArray"`
+	}{Mobile: mobile, TemplateId: templateId}
+	for name, value := range params {
+		reqBody.ParameterArray = append(reqBody.ParameterArray, parameter{Parameter: name, ParameterValue: value})
+	}
+
+	var resp struct {
+		apiEnvelope
+		VerificationCodeId float64 `json:"VerificationCodeId"`
+	}
+	result, err := c.do(ctx, http.MethodPost, "/Send/VerifyCode", token, reqBody, &resp)
+	if err != nil {
+		return "", err
+	}
+	id := formatNumericId(resp.VerificationCodeId)
+	if !resp.IsSuccessful {
+		return id, resp.err("SendByTemplate", ErrTemplateNotFound, result)
+	}
+	return id, nil
+}
@@ -1,14 +1,17 @@
 package sms_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"github.com/amirhosseinab/go-sms-ir/sms"
+	"github.com/amirhosseinab/go-sms-ir/sms/tokenstore"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -65,13 +68,13 @@ func TestGetCreditReturnValue(t *testing.T) {
 	invalidToken := "by_invalid_token"
 
 	td := []struct {
-		token   string
-		credit  int
-		error   error
-		message string
+		token    string
+		credit   int
+		sentinel error
+		message  string
 	}{
-		{token: validToken, credit: 1, error: nil, message: "valid token should not return error"},
-		{token: invalidToken, credit: 0, error: errors.New("invalid token"), message: "invalid token should return error"},
+		{token: validToken, credit: 1, sentinel: nil, message: "valid token should not return error"},
+		{token: invalidToken, credit: 0, sentinel: sms.ErrInvalidToken, message: "invalid token should return error"},
 	}
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -95,7 +98,7 @@ func TestGetCreditReturnValue(t *testing.T) {
 		t.Run(d.token, func(t *testing.T) {
 			c := sms.NewBulkSMSClient(createFakeToken(d.token), ts.URL)
 			credit, err := c.GetCredit()
-			if credit != d.credit || (err != nil && err.Error() != d.error.Error()) {
+			if credit != d.credit || !errors.Is(err, d.sentinel) {
 				t.Error(d.message)
 			}
 		})
@@ -306,13 +309,13 @@ func TestBulkSMS_SendVerificationCodeShouldReturnErrorForFailedRequests(t *testi
 	invalidMobile := "by_invalid_mobile"
 	validVId := "53160177228"
 	td := []struct {
-		mobile  string
-		vId     string
-		error   error
-		message string
+		mobile   string
+		vId      string
+		sentinel error
+		message  string
 	}{
-		{mobile: validMobile, vId: validVId, error: nil, message: "valid mobile should not return error"},
-		{mobile: invalidMobile, vId: "0", error: errors.New("invalid mobile"), message: "invalid mobile should return error"},
+		{mobile: validMobile, vId: validVId, sentinel: nil, message: "valid mobile should not return error"},
+		{mobile: invalidMobile, vId: "0", sentinel: sms.ErrInvalidMobile, message: "invalid mobile should return error"},
 	}
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -322,7 +325,9 @@ func TestBulkSMS_SendVerificationCodeShouldReturnErrorForFailedRequests(t *testi
 		}
 		var d data
 
-		body := struct{ MobileNumber string `json:"MobileNumber"` }{}
+		body := struct {
+			MobileNumber string `json:"MobileNumber"`
+		}{}
 		_ = json.NewDecoder(r.Body).Decode(&body)
 
 		if body.MobileNumber == validMobile {
@@ -341,9 +346,179 @@ func TestBulkSMS_SendVerificationCodeShouldReturnErrorForFailedRequests(t *testi
 		t.Run(d.mobile, func(t *testing.T) {
 			c := sms.NewBulkSMSClient(createFakeToken("fake_token"), ts.URL)
 			vId, err := c.SendVerificationCode(d.mobile, "fake_code")
-			if vId != d.vId || (err != nil && err.Error() != d.error.Error()) {
+			if vId != d.vId || !errors.Is(err, d.sentinel) {
 				t.Error(d.message)
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestBulkSMS_SendByTemplateShouldHasRequiredHeaders(t *testing.T) {
+	fakeToken := "fake_token"
+	gotToken := ""
+	gotContentType := ""
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotToken = r.Header.Get("x-sms-ir-secure-token")
+		gotContentType = r.Header.Get("Content-Type")
+	}))
+	defer ts.Close()
+
+	token := createFakeToken(fakeToken)
+	c := sms.NewBulkSMSClient(token, ts.URL)
+	_, _ = c.SendByTemplate("", 0, nil)
+	if gotToken != fakeToken {
+		t.Errorf("expected '%s', got '%s'", fakeToken, gotToken)
+	}
+	if gotContentType != "application/json" {
+		t.Errorf("expected '%s', got '%s'", "application/json", gotContentType)
+	}
+}
+
+func TestBulkSMS_SendByTemplateShouldSendsRequestBody(t *testing.T) {
+	mobile := "fake_mobile"
+	templateId := 123
+	params := map[string]string{"param1": "value1", "param2": "value2"}
+
+	type data struct {
+		Mobile         string `json:"Mobile"`
+		TemplateId     int    `json:"TemplateId"`
+		ParameterArray []struct {
+			Parameter      string `json:"Parameter"`
+			ParameterValue string `json:"ParameterValue"`
+		} `json:"ParameterArray"`
+	}
+
+	d := data{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&d)
+		defer r.Body.Close()
+	}))
+	defer ts.Close()
+
+	token := createFakeToken("fake_token")
+	c := sms.NewBulkSMSClient(token, ts.URL)
+	_, _ = c.SendByTemplate(mobile, templateId, params)
+
+	if d.Mobile != mobile {
+		t.Errorf("Expected Mobile: '%s', got '%s'", mobile, d.Mobile)
+	}
+	if d.TemplateId != templateId {
+		t.Errorf("Expected TemplateId: '%d', got '%d'", templateId, d.TemplateId)
+	}
+
+	if len(d.ParameterArray) != len(params) {
+		t.Fatalf("Expected paramters count: '%d', got '%d'", len(params), len(d.ParameterArray))
+	}
+}
+
+func TestGetCreditContextShouldAbortOnCancellation(t *testing.T) {
+	block := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-block
+	}))
+	defer ts.Close()
+	defer close(block)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	c := sms.NewBulkSMSClient(createFakeToken("fake_token"), ts.URL)
+	_, err := c.GetCreditContext(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got '%v'", err)
+	}
+}
+
+func TestGetCreditContextShouldRetryOnServerError(t *testing.T) {
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(struct {
+			Credit       int  `json:"Credit"`
+			IsSuccessful bool `json:"IsSuccessful"`
+		}{Credit: 5, IsSuccessful: true})
+	}))
+	defer ts.Close()
+
+	c := sms.NewBulkSMSClient(createFakeToken("fake_token"), ts.URL, sms.WithRetryPolicy(sms.RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+	}))
+	credit, err := c.GetCredit()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if credit != 5 {
+		t.Errorf("expected credit 5, got %d", credit)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestGetCreditContextShouldHonorRetryAfterHeader(t *testing.T) {
+	attempts := 0
+	var gotDelay time.Duration
+	start := time.Time{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			start = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		gotDelay = time.Since(start)
+		_ = json.NewEncoder(w).Encode(struct {
+			Credit       int  `json:"Credit"`
+			IsSuccessful bool `json:"IsSuccessful"`
+		}{Credit: 1, IsSuccessful: true})
+	}))
+	defer ts.Close()
+
+	c := sms.NewBulkSMSClient(createFakeToken("fake_token"), ts.URL, sms.WithRetryPolicy(sms.RetryPolicy{
+		MaxAttempts: 2,
+	}))
+	if _, err := c.GetCredit(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotDelay < 900*time.Millisecond {
+		t.Errorf("expected retry to wait for Retry-After, waited '%v'", gotDelay)
+	}
+}
+
+func TestGetTokenShouldCoalesceConcurrentFetches(t *testing.T) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		time.Sleep(20 * time.Millisecond)
+		data := struct {
+			TokenKey     string `json:"TokenKey"`
+			IsSuccessful bool   `json:"IsSuccessful"`
+		}{
+			TokenKey:     "fake_token",
+			IsSuccessful: true,
+		}
+		_ = json.NewEncoder(w).Encode(&data)
+	}))
+	defer ts.Close()
+
+	cfg := sms.Config{BaseURL: ts.URL, TokenStore: tokenstore.NewMemory()}
+	wg := &sync.WaitGroup{}
+	wg.Add(10)
+	for i := 0; i < 10; i++ {
+		go func() {
+			defer wg.Done()
+			_, _ = sms.NewToken(cfg).Get()
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("expected concurrent Get calls to coalesce into 1 request, got %d", got)
+	}
+}
@@ -0,0 +1,68 @@
+package sms
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/amirhosseinab/go-sms-ir/sms/tokenstore"
+)
+
+// Config holds the settings used to construct a Token. BaseURL has no
+// default: "/Token" is appended to it as given, so it must be set.
+type Config struct {
+	APIKey       string
+	SecretKey    string
+	BaseURL      string
+	DisableCache bool
+
+	// HTTPClient performs the underlying HTTP requests. If nil,
+	// http.DefaultClient is used.
+	HTTPClient *http.Client
+
+	// Timeout bounds each individual request attempt; it is applied fresh
+	// on every retry, so total wall-clock across a retried call can reach
+	// RetryPolicy.MaxAttempts*Timeout plus backoff. Zero means no timeout
+	// beyond whatever HTTPClient already enforces.
+	Timeout time.Duration
+
+	// RetryPolicy controls how failed requests are retried. The zero value
+	// disables retries.
+	RetryPolicy RetryPolicy
+
+	// TokenStore caches the fetched token so replicas don't each re-hit
+	// /Token. If nil, tokens are cached in process memory (and lost across
+	// restarts/replicas), unless DisableCache is set.
+	TokenStore tokenstore.Store
+}
+
+func (c Config) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// ClientOption configures a BulkSMSClient.
+type ClientOption func(*BulkSMSClient)
+
+// WithHTTPClient sets the http.Client used to perform requests.
+func WithHTTPClient(c *http.Client) ClientOption {
+	return func(bc *BulkSMSClient) {
+		bc.httpClient = c
+	}
+}
+
+// WithRetryPolicy sets the retry/backoff behavior for failed requests.
+func WithRetryPolicy(p RetryPolicy) ClientOption {
+	return func(bc *BulkSMSClient) {
+		bc.retryPolicy = p
+	}
+}
+
+// WithTimeout bounds each individual request attempt; see Config.Timeout
+// for how it interacts with retries.
+func WithTimeout(d time.Duration) ClientOption {
+	return func(bc *BulkSMSClient) {
+		bc.timeout = d
+	}
+}
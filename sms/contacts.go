@@ -0,0 +1,258 @@
+package sms
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ManagedContact is an entry in the account's contact book.
+type ManagedContact struct {
+	Id          int64   `json:"Id,omitempty"`
+	FirstName   string  `json:"FirstName"`
+	LastName    string  `json:"LastName"`
+	Mobile      string  `json:"Mobile"`
+	CategoryIds []int64 `json:"GroupIds,omitempty"`
+}
+
+// ManagedContactCategory groups ManagedContacts together (e.g. "VIP
+// customers") for targeted sends.
+type ManagedContactCategory struct {
+	Id   int64  `json:"Id,omitempty"`
+	Name string `json:"Name"`
+}
+
+// CreateManagedContact adds contact to the account's contact book and
+// returns its assigned id. It is equivalent to
+// CreateManagedContactContext(context.Background(), contact).
+func (c *BulkSMSClient) CreateManagedContact(contact ManagedContact) (int64, error) {
+	return c.CreateManagedContactContext(context.Background(), contact)
+}
+
+// CreateManagedContactContext is like CreateManagedContact but carries ctx
+// through the request.
+func (c *BulkSMSClient) CreateManagedContactContext(ctx context.Context, contact ManagedContact) (int64, error) {
+	token, err := c.token0(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var resp struct {
+		apiEnvelope
+		Id int64 `json:"Id"`
+	}
+	result, err := c.do(ctx, http.MethodPost, "/ManagedContact", token, contact, &resp)
+	if err != nil {
+		return 0, err
+	}
+	if !resp.IsSuccessful {
+		return 0, resp.err("CreateManagedContact", ErrInvalidMobile, result)
+	}
+	return resp.Id, nil
+}
+
+// GetManagedContacts returns a page of the account's contacts. pageNumber
+// is 1-indexed. It is equivalent to
+// GetManagedContactsContext(context.Background(), pageNumber).
+func (c *BulkSMSClient) GetManagedContacts(pageNumber int) ([]ManagedContact, error) {
+	return c.GetManagedContactsContext(context.Background(), pageNumber)
+}
+
+// GetManagedContactsContext is like GetManagedContacts but carries ctx
+// through the request.
+func (c *BulkSMSClient) GetManagedContactsContext(ctx context.Context, pageNumber int) ([]ManagedContact, error) {
+	token, err := c.token0(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		apiEnvelope
+		Contacts []ManagedContact `json:"Contacts"`
+	}
+	path := "/ManagedContact?" + url.Values{"PageNumber": {strconv.Itoa(pageNumber)}}.Encode()
+	result, err := c.do(ctx, http.MethodGet, path, token, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if !resp.IsSuccessful {
+		return nil, resp.err("GetManagedContacts", nil, result)
+	}
+	return resp.Contacts, nil
+}
+
+// UpdateManagedContact updates an existing contact, identified by
+// contact.Id. It is equivalent to
+// UpdateManagedContactContext(context.Background(), contact).
+func (c *BulkSMSClient) UpdateManagedContact(contact ManagedContact) error {
+	return c.UpdateManagedContactContext(context.Background(), contact)
+}
+
+// UpdateManagedContactContext is like UpdateManagedContact but carries ctx
+// through the request.
+func (c *BulkSMSClient) UpdateManagedContactContext(ctx context.Context, contact ManagedContact) error {
+	token, err := c.token0(ctx)
+	if err != nil {
+		return err
+	}
+
+	var resp struct {
+		apiEnvelope
+	}
+	result, err := c.do(ctx, http.MethodPut, "/ManagedContact", token, contact, &resp)
+	if err != nil {
+		return err
+	}
+	if !resp.IsSuccessful {
+		return resp.err("UpdateManagedContact", ErrInvalidMobile, result)
+	}
+	return nil
+}
+
+// DeleteManagedContact removes the contact identified by id. It is
+// equivalent to DeleteManagedContactContext(context.Background(), id).
+func (c *BulkSMSClient) DeleteManagedContact(id int64) error {
+	return c.DeleteManagedContactContext(context.Background(), id)
+}
+
+// DeleteManagedContactContext is like DeleteManagedContact but carries ctx
+// through the request.
+func (c *BulkSMSClient) DeleteManagedContactContext(ctx context.Context, id int64) error {
+	token, err := c.token0(ctx)
+	if err != nil {
+		return err
+	}
+
+	var resp struct {
+		apiEnvelope
+	}
+	path := "/ManagedContact?" + url.Values{"Id": {strconv.FormatInt(id, 10)}}.Encode()
+	result, err := c.do(ctx, http.MethodDelete, path, token, nil, &resp)
+	if err != nil {
+		return err
+	}
+	if !resp.IsSuccessful {
+		return resp.err("DeleteManagedContact", nil, result)
+	}
+	return nil
+}
+
+// CreateManagedContactCategory adds a new contact category and returns its
+// assigned id. It is equivalent to
+// CreateManagedContactCategoryContext(context.Background(), name).
+func (c *BulkSMSClient) CreateManagedContactCategory(name string) (int64, error) {
+	return c.CreateManagedContactCategoryContext(context.Background(), name)
+}
+
+// CreateManagedContactCategoryContext is like CreateManagedContactCategory
+// but carries ctx through the request.
+func (c *BulkSMSClient) CreateManagedContactCategoryContext(ctx context.Context, name string) (int64, error) {
+	token, err := c.token0(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	reqBody := struct {
+		Name string `json:"Name"`
+	}{Name: name}
+
+	var resp struct {
+		apiEnvelope
+		Id int64 `json:"Id"`
+	}
+	result, err := c.do(ctx, http.MethodPost, "/ManagedContactCategory", token, reqBody, &resp)
+	if err != nil {
+		return 0, err
+	}
+	if !resp.IsSuccessful {
+		return 0, resp.err("CreateManagedContactCategory", nil, result)
+	}
+	return resp.Id, nil
+}
+
+// GetManagedContactCategories returns every contact category on the
+// account. It is equivalent to
+// GetManagedContactCategoriesContext(context.Background()).
+func (c *BulkSMSClient) GetManagedContactCategories() ([]ManagedContactCategory, error) {
+	return c.GetManagedContactCategoriesContext(context.Background())
+}
+
+// GetManagedContactCategoriesContext is like GetManagedContactCategories
+// but carries ctx through the request.
+func (c *BulkSMSClient) GetManagedContactCategoriesContext(ctx context.Context) ([]ManagedContactCategory, error) {
+	token, err := c.token0(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		apiEnvelope
+		Categories []ManagedContactCategory `json:"Categories"`
+	}
+	result, err := c.do(ctx, http.MethodGet, "/ManagedContactCategory", token, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if !resp.IsSuccessful {
+		return nil, resp.err("GetManagedContactCategories", nil, result)
+	}
+	return resp.Categories, nil
+}
+
+// UpdateManagedContactCategory updates an existing category, identified by
+// category.Id. It is equivalent to
+// UpdateManagedContactCategoryContext(context.Background(), category).
+func (c *BulkSMSClient) UpdateManagedContactCategory(category ManagedContactCategory) error {
+	return c.UpdateManagedContactCategoryContext(context.Background(), category)
+}
+
+// UpdateManagedContactCategoryContext is like UpdateManagedContactCategory
+// but carries ctx through the request.
+func (c *BulkSMSClient) UpdateManagedContactCategoryContext(ctx context.Context, category ManagedContactCategory) error {
+	token, err := c.token0(ctx)
+	if err != nil {
+		return err
+	}
+
+	var resp struct {
+		apiEnvelope
+	}
+	result, err := c.do(ctx, http.MethodPut, "/ManagedContactCategory", token, category, &resp)
+	if err != nil {
+		return err
+	}
+	if !resp.IsSuccessful {
+		return resp.err("UpdateManagedContactCategory", nil, result)
+	}
+	return nil
+}
+
+// DeleteManagedContactCategory removes the category identified by id. It
+// is equivalent to
+// DeleteManagedContactCategoryContext(context.Background(), id).
+func (c *BulkSMSClient) DeleteManagedContactCategory(id int64) error {
+	return c.DeleteManagedContactCategoryContext(context.Background(), id)
+}
+
+// DeleteManagedContactCategoryContext is like
+// DeleteManagedContactCategory but carries ctx through the request.
+func (c *BulkSMSClient) DeleteManagedContactCategoryContext(ctx context.Context, id int64) error {
+	token, err := c.token0(ctx)
+	if err != nil {
+		return err
+	}
+
+	var resp struct {
+		apiEnvelope
+	}
+	path := "/ManagedContactCategory?" + url.Values{"Id": {strconv.FormatInt(id, 10)}}.Encode()
+	result, err := c.do(ctx, http.MethodDelete, path, token, nil, &resp)
+	if err != nil {
+		return err
+	}
+	if !resp.IsSuccessful {
+		return resp.err("DeleteManagedContactCategory", nil, result)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package sms
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// APIError is returned by every BulkSMSClient/Token method when SMS.ir
+// answers with IsSuccessful:false. Branch on the failure with errors.Is
+// against the Err* sentinels below, or pull HTTPStatus/Code/RetryAfter out
+// with errors.As.
+type APIError struct {
+	// Op names the failed operation, e.g. "SendVerificationCode".
+	Op         string
+	HTTPStatus int
+	// Code is SMS.ir's numeric status code, as reported in the response
+	// body.
+	Code int
+	// Message is SMS.ir's human-readable description of Code, if any.
+	Message string
+	// RetryAfter is how long SMS.ir asked callers to wait before retrying,
+	// parsed from the Retry-After header. Zero when absent.
+	RetryAfter time.Duration
+	// Raw is reserved for the unparsed response body. The APIError values
+	// built by this package's BulkSMSClient and Token methods leave it nil.
+	Raw json.RawMessage
+
+	sentinel *sentinelError
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("sms: %s: %s (code %d, http %d)", e.Op, e.Message, e.Code, e.HTTPStatus)
+	}
+	return fmt.Sprintf("sms: %s: code %d, http %d", e.Op, e.Code, e.HTTPStatus)
+}
+
+// Is reports whether target is the sentinel this APIError was constructed
+// against, so callers can write errors.Is(err, sms.ErrInvalidMobile).
+func (e *APIError) Is(target error) bool {
+	s, ok := target.(*sentinelError)
+	return ok && e.sentinel == s
+}
+
+// sentinelError is a comparable, zero-payload error identity. APIError
+// values carry a pointer to one so errors.Is can match on it without the
+// caller needing to know about the concrete APIError type.
+type sentinelError struct{ text string }
+
+func (s *sentinelError) Error() string { return s.text }
+
+var (
+	ErrInvalidToken       = &sentinelError{"sms: invalid token"}
+	ErrInvalidMobile      = &sentinelError{"sms: invalid mobile"}
+	ErrRateLimited        = &sentinelError{"sms: rate limited"}
+	ErrInsufficientCredit = &sentinelError{"sms: insufficient credit"}
+	ErrTemplateNotFound   = &sentinelError{"sms: template not found"}
+)
+
+// apiEnvelope is embedded in every decoded response struct so failures
+// carry SMS.ir's own code/message instead of a generic "it failed".
+type apiEnvelope struct {
+	IsSuccessful bool   `json:"IsSuccessful"`
+	Message      string `json:"Message"`
+	Code         int    `json:"Code"`
+}
+
+// err builds the APIError for this envelope, tagging it with sentinel so
+// errors.Is can identify it and result so HTTPStatus/RetryAfter reflect
+// what the server actually returned.
+func (e apiEnvelope) err(op string, sentinel *sentinelError, result requestResult) error {
+	return &APIError{
+		Op:         op,
+		HTTPStatus: result.StatusCode,
+		Code:       e.Code,
+		Message:    e.Message,
+		RetryAfter: result.RetryAfter,
+		sentinel:   sentinel,
+	}
+}
+
+// statusError builds the APIError returned when retries are exhausted
+// against a 429/5xx response whose body was never decoded (there's no
+// SMS.ir Message/Code to carry). 429 is tagged ErrRateLimited so callers
+// can drive backoff off errors.Is; other statuses carry no sentinel.
+func statusError(method, url string, result requestResult) error {
+	var sentinel *sentinelError
+	if result.StatusCode == http.StatusTooManyRequests {
+		sentinel = ErrRateLimited
+	}
+	return &APIError{
+		Op:         fmt.Sprintf("%s %s", method, url),
+		HTTPStatus: result.StatusCode,
+		RetryAfter: result.RetryAfter,
+		sentinel:   sentinel,
+	}
+}
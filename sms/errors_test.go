@@ -0,0 +1,66 @@
+package sms_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/amirhosseinab/go-sms-ir/sms"
+)
+
+func TestGetCreditShouldReturnAPIErrorWithStatusAndCode(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "2")
+		_ = json.NewEncoder(w).Encode(struct {
+			Credit       int    `json:"Credit"`
+			IsSuccessful bool   `json:"IsSuccessful"`
+			Message      string `json:"Message"`
+			Code         int    `json:"Code"`
+		}{IsSuccessful: false, Message: "insufficient credit", Code: 21})
+	}))
+	defer ts.Close()
+
+	c := sms.NewBulkSMSClient(createFakeToken("fake_token"), ts.URL)
+	_, err := c.GetCredit()
+
+	var apiErr *sms.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *sms.APIError, got %T: %v", err, err)
+	}
+	if apiErr.HTTPStatus != http.StatusOK {
+		t.Errorf("expected HTTPStatus %d, got %d", http.StatusOK, apiErr.HTTPStatus)
+	}
+	if apiErr.Code != 21 {
+		t.Errorf("expected Code 21, got %d", apiErr.Code)
+	}
+	if apiErr.RetryAfter <= 0 {
+		t.Errorf("expected a positive RetryAfter, got %v", apiErr.RetryAfter)
+	}
+}
+
+func TestGetCreditShouldReturnErrRateLimitedWhenRetriesExhausted(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	c := sms.NewBulkSMSClient(createFakeToken("fake_token"), ts.URL, sms.WithRetryPolicy(sms.RetryPolicy{
+		MaxAttempts: 2,
+		BaseDelay:   time.Millisecond,
+	}))
+	_, err := c.GetCredit()
+
+	if !errors.Is(err, sms.ErrRateLimited) {
+		t.Fatalf("expected errors.Is(err, sms.ErrRateLimited), got %v", err)
+	}
+	var apiErr *sms.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *sms.APIError, got %T: %v", err, err)
+	}
+	if apiErr.HTTPStatus != http.StatusTooManyRequests {
+		t.Errorf("expected HTTPStatus %d, got %d", http.StatusTooManyRequests, apiErr.HTTPStatus)
+	}
+}
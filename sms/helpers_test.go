@@ -0,0 +1,25 @@
+package sms_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/amirhosseinab/go-sms-ir/sms"
+)
+
+// createFakeToken returns a Token that always resolves to token without
+// the caller needing to stand up its own /Token fake server.
+func createFakeToken(token string) *sms.Token {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data := struct {
+			TokenKey     string `json:"TokenKey"`
+			IsSuccessful bool   `json:"IsSuccessful"`
+		}{
+			TokenKey:     token,
+			IsSuccessful: true,
+		}
+		_ = json.NewEncoder(w).Encode(&data)
+	}))
+	return sms.NewToken(sms.Config{BaseURL: ts.URL})
+}
@@ -0,0 +1,205 @@
+package sms
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// MessageStatus reports the delivery status of a single sent message.
+type MessageStatus struct {
+	MessageId int64 `json:"MessageId"`
+	// Status is SMS.ir's numeric delivery-status code (e.g. sent,
+	// delivered, failed).
+	Status int `json:"Status"`
+}
+
+// ReceivedMessage is an inbound SMS delivered to one of the account's line
+// numbers.
+type ReceivedMessage struct {
+	MessageId   int64  `json:"MessageId"`
+	Mobile      string `json:"Mobile"`
+	Text        string `json:"Text"`
+	LineNumber  string `json:"LineNumber"`
+	ReceiveDate string `json:"ReceiveDate"`
+}
+
+// Send dispatches messages in bulk from lineNumber to mobileNumbers,
+// optionally scheduled for sendDateTime (nil sends immediately). It
+// returns the resulting message ids, one per mobileNumbers entry in order.
+// It is equivalent to SendContext(context.Background(), ...).
+func (c *BulkSMSClient) Send(lineNumber string, messages []string, mobileNumbers []string, sendDateTime *time.Time) ([]int64, error) {
+	return c.SendContext(context.Background(), lineNumber, messages, mobileNumbers, sendDateTime)
+}
+
+// SendContext is like Send but carries ctx through the request.
+func (c *BulkSMSClient) SendContext(ctx context.Context, lineNumber string, messages []string, mobileNumbers []string, sendDateTime *time.Time) ([]int64, error) {
+	token, err := c.token0(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reqBody := struct {
+		LineNumber   string     `json:"LineNumber"`
+		MessageText  []string   `json:"MessageText"`
+		Mobiles      []string   `json:"Mobiles"`
+		SendDateTime *time.Time `json:"SendDateTime,omitempty"`
+	}{
+		LineNumber:   lineNumber,
+		MessageText:  messages,
+		Mobiles:      mobileNumbers,
+		SendDateTime: sendDateTime,
+	}
+
+	var resp struct {
+		apiEnvelope
+		MessageIds []int64 `json:"MessageIds"`
+	}
+	result, err := c.do(ctx, http.MethodPost, "/Send/Bulk", token, reqBody, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if !resp.IsSuccessful {
+		return resp.MessageIds, resp.err("Send", ErrInsufficientCredit, result)
+	}
+	return resp.MessageIds, nil
+}
+
+// GetStatusByBatchKey returns the delivery status of every message sent in
+// the batch identified by batchKey (the key SMS.ir assigns a Send call).
+// It is equivalent to GetStatusByBatchKeyContext(context.Background(), batchKey).
+func (c *BulkSMSClient) GetStatusByBatchKey(batchKey int64) ([]MessageStatus, error) {
+	return c.GetStatusByBatchKeyContext(context.Background(), batchKey)
+}
+
+// GetStatusByBatchKeyContext is like GetStatusByBatchKey but carries ctx
+// through the request.
+func (c *BulkSMSClient) GetStatusByBatchKeyContext(ctx context.Context, batchKey int64) ([]MessageStatus, error) {
+	token, err := c.token0(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		apiEnvelope
+		Statuses []MessageStatus `json:"Statuses"`
+	}
+	path := "/Send/Status/Batch?" + url.Values{"BatchKey": {strconv.FormatInt(batchKey, 10)}}.Encode()
+	result, err := c.do(ctx, http.MethodGet, path, token, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if !resp.IsSuccessful {
+		return nil, resp.err("GetStatusByBatchKey", nil, result)
+	}
+	return resp.Statuses, nil
+}
+
+// GetStatusByMessageId returns the delivery status of a single message. It
+// is equivalent to GetStatusByMessageIdContext(context.Background(), messageId).
+func (c *BulkSMSClient) GetStatusByMessageId(messageId int64) (MessageStatus, error) {
+	return c.GetStatusByMessageIdContext(context.Background(), messageId)
+}
+
+// GetStatusByMessageIdContext is like GetStatusByMessageId but carries ctx
+// through the request.
+func (c *BulkSMSClient) GetStatusByMessageIdContext(ctx context.Context, messageId int64) (MessageStatus, error) {
+	token, err := c.token0(ctx)
+	if err != nil {
+		return MessageStatus{}, err
+	}
+
+	var resp struct {
+		apiEnvelope
+		MessageStatus
+	}
+	path := "/Send/Status/Message?" + url.Values{"MessageId": {strconv.FormatInt(messageId, 10)}}.Encode()
+	result, err := c.do(ctx, http.MethodGet, path, token, nil, &resp)
+	if err != nil {
+		return MessageStatus{}, err
+	}
+	if !resp.IsSuccessful {
+		return MessageStatus{}, resp.err("GetStatusByMessageId", nil, result)
+	}
+	return resp.MessageStatus, nil
+}
+
+// GetReceivedMessages returns a page of inbound messages. pageNumber is
+// 1-indexed; shamsiDate (e.g. "1403/05/01") filters to that Jalali day and
+// may be empty to return the most recent messages. It is equivalent to
+// GetReceivedMessagesContext(context.Background(), pageNumber, shamsiDate).
+func (c *BulkSMSClient) GetReceivedMessages(pageNumber int, shamsiDate string) ([]ReceivedMessage, error) {
+	return c.GetReceivedMessagesContext(context.Background(), pageNumber, shamsiDate)
+}
+
+// GetReceivedMessagesContext is like GetReceivedMessages but carries ctx
+// through the request.
+func (c *BulkSMSClient) GetReceivedMessagesContext(ctx context.Context, pageNumber int, shamsiDate string) ([]ReceivedMessage, error) {
+	token, err := c.token0(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		apiEnvelope
+		Messages []ReceivedMessage `json:"Messages"`
+	}
+	q := url.Values{"PageNumber": {strconv.Itoa(pageNumber)}}
+	if shamsiDate != "" {
+		q.Set("ShamsiDate", shamsiDate)
+	}
+	path := "/Receive?" + q.Encode()
+	result, err := c.do(ctx, http.MethodGet, path, token, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if !resp.IsSuccessful {
+		return nil, resp.err("GetReceivedMessages", nil, result)
+	}
+	return resp.Messages, nil
+}
+
+// UltraFastSend sends a templated message the same way SendByTemplate
+// does, but over SMS.ir's low-latency path meant for OTP-style codes. It
+// is equivalent to UltraFastSendContext(context.Background(), ...).
+func (c *BulkSMSClient) UltraFastSend(templateId int, params map[string]string, mobile string) (string, error) {
+	return c.UltraFastSendContext(context.Background(), templateId, params, mobile)
+}
+
+// UltraFastSendContext is like UltraFastSend but carries ctx through the
+// request.
+func (c *BulkSMSClient) UltraFastSendContext(ctx context.Context, templateId int, params map[string]string, mobile string) (string, error) {
+	token, err := c.token0(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	type parameter struct {
+		Parameter      string `json:"Parameter"`
+		ParameterValue string `json:"ParameterValue"`
+	}
+	reqBody := struct {
+		Mobile         string      `json:"Mobile"`
+		TemplateId     int         `json:"TemplateId"`
+		ParameterArray []parameter `json:"ParameterArray"`
+	}{Mobile: mobile, TemplateId: templateId}
+	for name, value := range params {
+		reqBody.ParameterArray = append(reqBody.ParameterArray, parameter{Parameter: name, ParameterValue: value})
+	}
+
+	var resp struct {
+		apiEnvelope
+		MessageId float64 `json:"MessageId"`
+	}
+	result, err := c.do(ctx, http.MethodPost, "/Send/UltraFastSend", token, reqBody, &resp)
+	if err != nil {
+		return "", err
+	}
+	id := formatNumericId(resp.MessageId)
+	if !resp.IsSuccessful {
+		return id, resp.err("UltraFastSend", ErrTemplateNotFound, result)
+	}
+	return id, nil
+}
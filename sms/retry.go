@@ -0,0 +1,93 @@
+package sms
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how a client retries a failed request. The zero
+// value disables retries (MaxAttempts of 0 means "try once, don't retry").
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+	// BaseDelay is the upper bound of the delay before the first retry;
+	// jitter picks the actual delay at or below it. Subsequent retries
+	// back off exponentially from this value.
+	BaseDelay time.Duration
+	// MaxDelay caps the computed backoff, regardless of attempt count.
+	MaxDelay time.Duration
+}
+
+func (p RetryPolicy) enabled() bool {
+	return p.MaxAttempts > 1
+}
+
+// backoff returns how long to wait before the given retry attempt
+// (1-indexed: the delay before the first retry is backoff(1)). It applies
+// full jitter in [0, computed delay].
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	base := p.BaseDelay
+	if base <= 0 {
+		base = 200 * time.Millisecond
+	}
+	max := p.MaxDelay
+	if max <= 0 {
+		max = 30 * time.Second
+	}
+
+	delay := base << uint(attempt-1)
+	if delay <= 0 || delay > max {
+		delay = max
+	}
+	return time.Duration(rand.Int63n(int64(delay) + 1))
+}
+
+// retryAfter parses the Retry-After header (RFC 7231), which may be either
+// a number of seconds or an HTTP date. It returns false when the header is
+// absent or unparsable.
+func retryAfter(h http.Header) (time.Duration, bool) {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+	if when, err := http.ParseTime(v); err == nil {
+		if d := time.Until(when); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// shouldRetry reports whether a failed attempt for method is safe to retry.
+// resp is checked first: once a response comes back at all, 429/5xx are
+// known-bad outcomes and safe to retry regardless of method, since the
+// server is known to have rejected that attempt rather than leaving it
+// ambiguous. Only when resp is nil — a genuine transport-level error, so
+// it's ambiguous whether the request ever reached the server — do we fall
+// back to method: retrying a non-idempotent POST in that situation risks
+// sending an SMS or verification code twice, so it's limited to idempotent
+// methods (GET/HEAD/OPTIONS).
+func shouldRetry(method string, resp *http.Response, err error) bool {
+	if resp != nil {
+		return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	}
+	if err != nil {
+		return isIdempotentMethod(method)
+	}
+	return false
+}
+
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
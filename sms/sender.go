@@ -0,0 +1,227 @@
+package sms
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrSenderQueueFull is returned by Enqueue when the Sender's queue is at
+// QueueCapacity and can't accept another Message without blocking.
+var ErrSenderQueueFull = errors.New("sms: sender queue full")
+
+// ErrSenderStopped is returned by Enqueue after Shutdown has been called.
+var ErrSenderStopped = errors.New("sms: sender stopped")
+
+// Message is one SMS queued on a Sender.
+type Message struct {
+	LineNumber   string
+	Text         string
+	Mobile       string
+	SendDateTime *time.Time
+}
+
+// SendResult reports the outcome of sending a single queued Message.
+type SendResult struct {
+	Message   Message
+	MessageId int64
+	Err       error
+}
+
+// SenderOptions configures a Sender.
+type SenderOptions struct {
+	// RatePerSecond caps how many batches Sender dispatches per second.
+	// Zero or negative disables rate limiting.
+	RatePerSecond float64
+	// Workers is how many goroutines pull batches off the queue and send
+	// them concurrently. Defaults to 1.
+	Workers int
+	// MaxBatchSize is the most messages grouped into a single bulk Send
+	// call. Defaults to 50.
+	MaxBatchSize int
+	// QueueCapacity bounds how many messages Enqueue can buffer before it
+	// returns ErrSenderQueueFull. Defaults to 100.
+	QueueCapacity int
+	// OnResult, if set, is called once per queued Message with its result.
+	OnResult func(SendResult)
+}
+
+func (o SenderOptions) withDefaults() SenderOptions {
+	if o.Workers <= 0 {
+		o.Workers = 1
+	}
+	if o.MaxBatchSize <= 0 {
+		o.MaxBatchSize = 50
+	}
+	if o.QueueCapacity <= 0 {
+		o.QueueCapacity = 100
+	}
+	return o
+}
+
+// Sender batches queued Messages into bulk Send calls, spread out to
+// respect RatePerSecond, and fans the work across a worker pool.
+type Sender struct {
+	client  *BulkSMSClient
+	opts    SenderOptions
+	limiter *tokenBucket
+
+	queue chan Message
+	wg    sync.WaitGroup
+
+	stopOnce sync.Once
+	stopped  chan struct{}
+}
+
+// NewSender creates a Sender that dispatches through client according to
+// opts.
+func NewSender(client *BulkSMSClient, opts SenderOptions) *Sender {
+	opts = opts.withDefaults()
+
+	s := &Sender{
+		client:  client,
+		opts:    opts,
+		queue:   make(chan Message, opts.QueueCapacity),
+		stopped: make(chan struct{}),
+	}
+	if opts.RatePerSecond > 0 {
+		s.limiter = newTokenBucket(opts.RatePerSecond)
+	}
+
+	for i := 0; i < opts.Workers; i++ {
+		s.wg.Add(1)
+		go s.run()
+	}
+	return s
+}
+
+// Enqueue queues msg for sending. It does not block: it either succeeds
+// immediately or returns ErrSenderQueueFull if the queue is at
+// QueueCapacity, or ErrSenderStopped if Shutdown has already been called.
+func (s *Sender) Enqueue(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	select {
+	case <-s.stopped:
+		return ErrSenderStopped
+	default:
+	}
+
+	select {
+	case s.queue <- msg:
+		return nil
+	default:
+		return ErrSenderQueueFull
+	}
+}
+
+// Shutdown stops accepting new messages and waits for every queued message
+// to be sent, or for ctx to be done, whichever comes first.
+func (s *Sender) Shutdown(ctx context.Context) error {
+	s.stopOnce.Do(func() {
+		close(s.stopped)
+		close(s.queue)
+	})
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Sender) run() {
+	defer s.wg.Done()
+	for {
+		msg, ok := <-s.queue
+		if !ok {
+			return
+		}
+
+		batch := []Message{msg}
+	collect:
+		for len(batch) < s.opts.MaxBatchSize {
+			select {
+			case m, ok := <-s.queue:
+				if !ok {
+					break collect
+				}
+				batch = append(batch, m)
+			default:
+				break collect
+			}
+		}
+
+		s.dispatch(batch)
+	}
+}
+
+// dispatch groups batch by LineNumber (Send requires a single line number
+// per call) and sends each group in turn.
+func (s *Sender) dispatch(batch []Message) {
+	var order []string
+	groups := map[string][]Message{}
+	for _, m := range batch {
+		if _, ok := groups[m.LineNumber]; !ok {
+			order = append(order, m.LineNumber)
+		}
+		groups[m.LineNumber] = append(groups[m.LineNumber], m)
+	}
+
+	for _, lineNumber := range order {
+		s.sendGroup(lineNumber, groups[lineNumber])
+	}
+}
+
+func (s *Sender) sendGroup(lineNumber string, msgs []Message) {
+	ctx := context.Background()
+
+	if s.limiter != nil {
+		if err := s.limiter.wait(ctx); err != nil {
+			s.reportAll(msgs, err)
+			return
+		}
+	}
+
+	texts := make([]string, len(msgs))
+	mobiles := make([]string, len(msgs))
+	var sendDateTime *time.Time
+	for i, m := range msgs {
+		texts[i] = m.Text
+		mobiles[i] = m.Mobile
+		if m.SendDateTime != nil {
+			sendDateTime = m.SendDateTime
+		}
+	}
+
+	ids, err := s.client.SendContext(ctx, lineNumber, texts, mobiles, sendDateTime)
+	for i, m := range msgs {
+		result := SendResult{Message: m, Err: err}
+		if err == nil && i < len(ids) {
+			result.MessageId = ids[i]
+		}
+		s.report(result)
+	}
+}
+
+func (s *Sender) reportAll(msgs []Message, err error) {
+	for _, m := range msgs {
+		s.report(SendResult{Message: m, Err: err})
+	}
+}
+
+func (s *Sender) report(r SendResult) {
+	if s.opts.OnResult != nil {
+		s.opts.OnResult(r)
+	}
+}
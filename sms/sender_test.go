@@ -0,0 +1,188 @@
+package sms_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/amirhosseinab/go-sms-ir/sms"
+)
+
+func TestSenderGroupsMessagesIntoBatches(t *testing.T) {
+	var mu sync.Mutex
+	var requestSizes []int
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Mobiles []string `json:"Mobiles"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		mu.Lock()
+		requestSizes = append(requestSizes, len(body.Mobiles))
+		mu.Unlock()
+
+		ids := make([]int64, len(body.Mobiles))
+		_ = json.NewEncoder(w).Encode(struct {
+			MessageIds   []int64 `json:"MessageIds"`
+			IsSuccessful bool    `json:"IsSuccessful"`
+		}{MessageIds: ids, IsSuccessful: true})
+	}))
+	defer ts.Close()
+
+	client := sms.NewBulkSMSClient(createFakeToken("fake_token"), ts.URL)
+	var results sync.WaitGroup
+	results.Add(5)
+	sender := sms.NewSender(client, sms.SenderOptions{
+		Workers:      1,
+		MaxBatchSize: 5,
+		OnResult:     func(sms.SendResult) { results.Done() },
+	})
+
+	for i := 0; i < 5; i++ {
+		if err := sender.Enqueue(context.Background(), sms.Message{LineNumber: "3000", Mobile: "0912", Text: "hi"}); err != nil {
+			t.Fatalf("unexpected Enqueue error: %v", err)
+		}
+	}
+
+	results.Wait()
+	if err := sender.Shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected Shutdown error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(requestSizes) != 1 || requestSizes[0] != 5 {
+		t.Errorf("expected a single batch of 5, got batches %v", requestSizes)
+	}
+}
+
+func TestSenderHonorsRatePerSecond(t *testing.T) {
+	var mu sync.Mutex
+	var gaps []time.Duration
+	last := time.Time{}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		now := time.Now()
+		if !last.IsZero() {
+			gaps = append(gaps, now.Sub(last))
+		}
+		last = now
+		mu.Unlock()
+
+		var body struct {
+			Mobiles []string `json:"Mobiles"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_ = json.NewEncoder(w).Encode(struct {
+			MessageIds   []int64 `json:"MessageIds"`
+			IsSuccessful bool    `json:"IsSuccessful"`
+		}{MessageIds: make([]int64, len(body.Mobiles)), IsSuccessful: true})
+	}))
+	defer ts.Close()
+
+	client := sms.NewBulkSMSClient(createFakeToken("fake_token"), ts.URL)
+	var results sync.WaitGroup
+	results.Add(3)
+	sender := sms.NewSender(client, sms.SenderOptions{
+		Workers:       1,
+		MaxBatchSize:  1,
+		RatePerSecond: 10,
+		OnResult:      func(sms.SendResult) { results.Done() },
+	})
+
+	for i := 0; i < 3; i++ {
+		_ = sender.Enqueue(context.Background(), sms.Message{LineNumber: "3000", Mobile: "0912", Text: "hi"})
+	}
+
+	results.Wait()
+	_ = sender.Shutdown(context.Background())
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, gap := range gaps {
+		if gap < 90*time.Millisecond {
+			t.Errorf("expected batches spaced >= 100ms apart at 10/s, got gap %v", gap)
+		}
+	}
+}
+
+func TestSenderRetriesOnRateLimitWithoutDroppingItems(t *testing.T) {
+	var attempts int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		var body struct {
+			Mobiles []string `json:"Mobiles"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_ = json.NewEncoder(w).Encode(struct {
+			MessageIds   []int64 `json:"MessageIds"`
+			IsSuccessful bool    `json:"IsSuccessful"`
+		}{MessageIds: make([]int64, len(body.Mobiles)), IsSuccessful: true})
+	}))
+	defer ts.Close()
+
+	client := sms.NewBulkSMSClient(createFakeToken("fake_token"), ts.URL, sms.WithRetryPolicy(sms.RetryPolicy{
+		MaxAttempts: 2,
+		BaseDelay:   time.Millisecond,
+	}))
+
+	var mu sync.Mutex
+	var result sms.SendResult
+	var results sync.WaitGroup
+	results.Add(1)
+	sender := sms.NewSender(client, sms.SenderOptions{
+		Workers:      1,
+		MaxBatchSize: 1,
+		OnResult: func(r sms.SendResult) {
+			mu.Lock()
+			result = r
+			mu.Unlock()
+			results.Done()
+		},
+	})
+
+	if err := sender.Enqueue(context.Background(), sms.Message{LineNumber: "3000", Mobile: "0912", Text: "hi"}); err != nil {
+		t.Fatalf("unexpected Enqueue error: %v", err)
+	}
+
+	results.Wait()
+	_ = sender.Shutdown(context.Background())
+
+	mu.Lock()
+	defer mu.Unlock()
+	if result.Err != nil {
+		t.Errorf("expected retry to recover from 429, got error: %v", result.Err)
+	}
+	if attempts != 2 {
+		t.Errorf("expected 2 attempts (1 rate limited + 1 retry), got %d", attempts)
+	}
+}
+
+func TestSenderEnqueueReturnsErrQueueFullAtCapacity(t *testing.T) {
+	block := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-block
+	}))
+	defer ts.Close()
+	defer close(block)
+
+	client := sms.NewBulkSMSClient(createFakeToken("fake_token"), ts.URL)
+	sender := sms.NewSender(client, sms.SenderOptions{Workers: 1, MaxBatchSize: 1, QueueCapacity: 1})
+
+	_ = sender.Enqueue(context.Background(), sms.Message{LineNumber: "3000", Mobile: "0912", Text: "hi"})
+	time.Sleep(20 * time.Millisecond) // let the worker pick up the first message and block on the server
+	_ = sender.Enqueue(context.Background(), sms.Message{LineNumber: "3000", Mobile: "0912", Text: "hi"})
+	if err := sender.Enqueue(context.Background(), sms.Message{LineNumber: "3000", Mobile: "0912", Text: "hi"}); err != sms.ErrSenderQueueFull {
+		t.Errorf("expected ErrSenderQueueFull, got %v", err)
+	}
+}
@@ -0,0 +1,151 @@
+package sms
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/amirhosseinab/go-sms-ir/sms/tokenstore"
+)
+
+// defaultTokenTTL is how long a fetched token is assumed valid for when
+// caching is enabled. SMS.ir doesn't return an explicit expiry in the
+// /Token response.
+const defaultTokenTTL = 24 * time.Hour
+
+// defaultStore backs every Token that doesn't set Config.TokenStore, so
+// that separate Token values built from equivalent Config (e.g. one per
+// request in a web handler) still share a single cached token.
+var defaultStore = tokenstore.NewMemory()
+
+func cacheKey(cfg Config) string {
+	return cfg.BaseURL + "|" + cfg.APIKey + "|" + cfg.SecretKey
+}
+
+// Token fetches and optionally caches the secure token SMS.ir requires on
+// every BulkSMSClient request.
+type Token struct {
+	cfg Config
+}
+
+// NewToken creates a Token from the given Config.
+func NewToken(cfg Config) *Token {
+	return &Token{cfg: cfg}
+}
+
+// Get returns the cached token if still valid, otherwise fetches a new one
+// from SMS.ir. It is equivalent to GetContext(context.Background()).
+func (t *Token) Get() (string, error) {
+	return t.GetContext(context.Background())
+}
+
+// GetContext is like Get but carries ctx through the underlying HTTP
+// request, so callers can cancel or bound it with a deadline. Concurrent
+// GetContext calls for the same Config coalesce into a single /Token
+// request.
+func (t *Token) GetContext(ctx context.Context) (string, error) {
+	key := cacheKey(t.cfg)
+	store := t.store()
+
+	if !t.cfg.DisableCache {
+		if token, expiresAt, ok := store.Get(key); ok && time.Now().Before(expiresAt) {
+			return token, nil
+		}
+	}
+
+	return tokenFetches.do(key, func() (string, error) {
+		if !t.cfg.DisableCache {
+			if token, expiresAt, ok := store.Get(key); ok && time.Now().Before(expiresAt) {
+				return token, nil
+			}
+		}
+
+		token, err := t.fetch(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		if !t.cfg.DisableCache {
+			_ = store.Set(key, token, time.Now().Add(defaultTokenTTL))
+		}
+		return token, nil
+	})
+}
+
+func (t *Token) store() tokenstore.Store {
+	if t.cfg.TokenStore != nil {
+		return t.cfg.TokenStore
+	}
+	return defaultStore
+}
+
+func (t *Token) fetch(ctx context.Context) (string, error) {
+	reqBody := struct {
+		UserApiKey string `json:"UserApiKey"`
+		SecretKey  string `json:"SecretKey"`
+	}{
+		UserApiKey: t.cfg.APIKey,
+		SecretKey:  t.cfg.SecretKey,
+	}
+
+	var resp struct {
+		apiEnvelope
+		TokenKey string `json:"TokenKey"`
+	}
+
+	result, err := doJSONRequest(ctx, t.cfg.httpClient(), t.cfg.RetryPolicy, t.cfg.Timeout,
+		http.MethodPost, t.cfg.BaseURL+"/Token", "", reqBody, &resp)
+	if err != nil {
+		return "", err
+	}
+	if !resp.IsSuccessful {
+		return "", resp.err("Token.Get", ErrInvalidToken, result)
+	}
+	return resp.TokenKey, nil
+}
+
+// tokenFetches coalesces concurrent fetches for the same cache key into a
+// single in-flight call, so N goroutines racing to refresh an expired
+// token only hit /Token once.
+var tokenFetches callGroup
+
+// callGroup is a minimal single-flight: the first caller for a given key
+// runs fn, and every other caller that arrives before it finishes waits
+// for and shares its result.
+type callGroup struct {
+	mu    sync.Mutex
+	calls map[string]*pendingCall
+}
+
+type pendingCall struct {
+	wg    sync.WaitGroup
+	token string
+	err   error
+}
+
+func (g *callGroup) do(key string, fn func() (string, error)) (string, error) {
+	g.mu.Lock()
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		call.wg.Wait()
+		return call.token, call.err
+	}
+
+	call := &pendingCall{}
+	call.wg.Add(1)
+	if g.calls == nil {
+		g.calls = map[string]*pendingCall{}
+	}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	call.token, call.err = fn()
+	call.wg.Done()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+
+	return call.token, call.err
+}
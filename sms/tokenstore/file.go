@@ -0,0 +1,92 @@
+package tokenstore
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+type fileEntry struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// File persists tokens as JSON on disk at path, so a single-instance
+// deployment keeps its token across restarts instead of re-fetching it
+// every time the process starts.
+type File struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFile creates a File store backed by path. The file is created lazily
+// on the first Set or Delete.
+func NewFile(path string) *File {
+	return &File{path: path}
+}
+
+func (f *File) Get(key string) (string, time.Time, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	entries, err := f.load()
+	if err != nil {
+		return "", time.Time{}, false
+	}
+	e, ok := entries[key]
+	if !ok {
+		return "", time.Time{}, false
+	}
+	return e.Token, e.ExpiresAt, true
+}
+
+func (f *File) Set(key, token string, expiresAt time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	entries, err := f.load()
+	if err != nil {
+		entries = map[string]fileEntry{}
+	}
+	entries[key] = fileEntry{Token: token, ExpiresAt: expiresAt}
+	return f.save(entries)
+}
+
+func (f *File) Delete(key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	entries, err := f.load()
+	if err != nil {
+		return nil
+	}
+	delete(entries, key)
+	return f.save(entries)
+}
+
+func (f *File) load() (map[string]fileEntry, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]fileEntry{}, nil
+		}
+		return nil, err
+	}
+	entries := map[string]fileEntry{}
+	if len(data) == 0 {
+		return entries, nil
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (f *File) save(entries map[string]fileEntry) error {
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(f.path, data, 0o600)
+}
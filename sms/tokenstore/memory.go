@@ -0,0 +1,50 @@
+package tokenstore
+
+import (
+	"sync"
+	"time"
+)
+
+type memoryEntry struct {
+	token     string
+	expiresAt time.Time
+}
+
+// Memory keeps tokens in process memory. It's the default Store, and is
+// lost on restart, so multiple replicas each end up with their own copy.
+type Memory struct {
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+}
+
+// NewMemory creates an empty Memory store.
+func NewMemory() *Memory {
+	return &Memory{entries: map[string]memoryEntry{}}
+}
+
+func (m *Memory) Get(key string) (string, time.Time, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entries[key]
+	if !ok {
+		return "", time.Time{}, false
+	}
+	return e.token, e.expiresAt, true
+}
+
+func (m *Memory) Set(key, token string, expiresAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.entries == nil {
+		m.entries = map[string]memoryEntry{}
+	}
+	m.entries[key] = memoryEntry{token: token, expiresAt: expiresAt}
+	return nil
+}
+
+func (m *Memory) Delete(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.entries, key)
+	return nil
+}
@@ -0,0 +1,66 @@
+package tokenstore
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// RedisClient is the subset of a Redis client Redis needs. It's satisfied
+// by a thin adapter over whichever Redis driver the caller already depends
+// on (e.g. go-redis), so this module doesn't have to pick one for them.
+type RedisClient interface {
+	Get(ctx context.Context, key string) (string, error)
+	Set(ctx context.Context, key string, value string, ttl time.Duration) error
+	Del(ctx context.Context, key string) error
+}
+
+type redisEntry struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Redis stores tokens in Redis under key prefixed by prefix. It's the
+// right choice for multi-replica deployments that want every replica to
+// share one token instead of each fetching its own.
+type Redis struct {
+	client RedisClient
+	prefix string
+}
+
+// NewRedis creates a Redis store using client, namespacing every key under
+// prefix.
+func NewRedis(client RedisClient, prefix string) *Redis {
+	return &Redis{client: client, prefix: prefix}
+}
+
+func (r *Redis) Get(key string) (string, time.Time, bool) {
+	raw, err := r.client.Get(context.Background(), r.prefix+key)
+	if err != nil || raw == "" {
+		return "", time.Time{}, false
+	}
+	var e redisEntry
+	if err := json.Unmarshal([]byte(raw), &e); err != nil {
+		return "", time.Time{}, false
+	}
+	if !time.Now().Before(e.ExpiresAt) {
+		return "", time.Time{}, false
+	}
+	return e.Token, e.ExpiresAt, true
+}
+
+func (r *Redis) Set(key, token string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	data, err := json.Marshal(redisEntry{Token: token, ExpiresAt: expiresAt})
+	if err != nil {
+		return err
+	}
+	return r.client.Set(context.Background(), r.prefix+key, string(data), ttl)
+}
+
+func (r *Redis) Delete(key string) error {
+	return r.client.Del(context.Background(), r.prefix+key)
+}
@@ -0,0 +1,17 @@
+// Package tokenstore defines where sms.Token caches the secure token it
+// gets back from SMS.ir, and ships a few ready-made backends.
+package tokenstore
+
+import "time"
+
+// Store persists a token under key, alongside when it expires.
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Get reports the cached token for key, if any. ok is false when
+	// nothing is cached. An implementation may return an entry whose
+	// expiresAt has passed (Memory and File do; Redis reports it as a
+	// miss), so callers compare expiresAt themselves.
+	Get(key string) (token string, expiresAt time.Time, ok bool)
+	Set(key, token string, expiresAt time.Time) error
+	Delete(key string) error
+}
@@ -0,0 +1,114 @@
+package tokenstore_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/amirhosseinab/go-sms-ir/sms/tokenstore"
+)
+
+func TestMemoryStoreRoundTrip(t *testing.T) {
+	s := tokenstore.NewMemory()
+	if _, _, ok := s.Get("k"); ok {
+		t.Fatalf("expected miss on empty store")
+	}
+
+	if err := s.Set("k", "tok", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	token, _, ok := s.Get("k")
+	if !ok || token != "tok" {
+		t.Fatalf("expected ('tok', true), got (%q, %v)", token, ok)
+	}
+
+	if err := s.Delete("k"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, _, ok := s.Get("k"); ok {
+		t.Fatalf("expected miss after delete")
+	}
+}
+
+func TestFileStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens.json")
+	s := tokenstore.NewFile(path)
+
+	if _, _, ok := s.Get("k"); ok {
+		t.Fatalf("expected miss before file exists")
+	}
+
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	if err := s.Set("k", "tok", expiresAt); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	reopened := tokenstore.NewFile(path)
+	token, got, ok := reopened.Get("k")
+	if !ok || token != "tok" || !got.Equal(expiresAt) {
+		t.Fatalf("expected ('tok', %v, true), got (%q, %v, %v)", expiresAt, token, got, ok)
+	}
+
+	if err := reopened.Delete("k"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, _, ok := tokenstore.NewFile(path).Get("k"); ok {
+		t.Fatalf("expected miss after delete")
+	}
+}
+
+type fakeRedisClient struct {
+	data map[string]string
+}
+
+func (f *fakeRedisClient) Get(_ context.Context, key string) (string, error) {
+	return f.data[key], nil
+}
+
+func (f *fakeRedisClient) Set(_ context.Context, key, value string, _ time.Duration) error {
+	f.data[key] = value
+	return nil
+}
+
+func (f *fakeRedisClient) Del(_ context.Context, key string) error {
+	delete(f.data, key)
+	return nil
+}
+
+func TestRedisStoreRoundTrip(t *testing.T) {
+	client := &fakeRedisClient{data: map[string]string{}}
+	s := tokenstore.NewRedis(client, "sms:")
+
+	expiresAt := time.Now().Add(time.Hour)
+	if err := s.Set("k", "tok", expiresAt); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := client.data["sms:k"]; !ok {
+		t.Fatalf("expected key to be namespaced with prefix")
+	}
+
+	token, _, ok := s.Get("k")
+	if !ok || token != "tok" {
+		t.Fatalf("expected ('tok', true), got (%q, %v)", token, ok)
+	}
+
+	if err := s.Delete("k"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, _, ok := s.Get("k"); ok {
+		t.Fatalf("expected miss after delete")
+	}
+}
+
+func TestRedisStoreSkipsAlreadyExpiredToken(t *testing.T) {
+	client := &fakeRedisClient{data: map[string]string{}}
+	s := tokenstore.NewRedis(client, "sms:")
+
+	if err := s.Set("k", "tok", time.Now().Add(-time.Minute)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, _, ok := s.Get("k"); ok {
+		t.Fatalf("expected already-expired token not to be stored")
+	}
+}
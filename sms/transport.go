@@ -0,0 +1,128 @@
+package sms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// requestResult carries the outcome of the underlying HTTP round trip back
+// to the caller, so it can build an APIError with the right HTTP status and
+// Retry-After even when the JSON body decoded cleanly but reported failure.
+type requestResult struct {
+	StatusCode int
+	RetryAfter time.Duration
+}
+
+// doJSONRequest executes method against url, optionally marshaling body as
+// the JSON request payload and unmarshaling the JSON response into out. It
+// retries according to policy, honoring Retry-After on 429/5xx responses,
+// and aborts immediately when ctx is canceled.
+func doJSONRequest(ctx context.Context, hc *http.Client, policy RetryPolicy, timeout time.Duration, method, url, token string, body, out interface{}) (requestResult, error) {
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		if err != nil {
+			return requestResult{}, fmt.Errorf("sms: marshal request body: %w", err)
+		}
+	}
+
+	attempts := 1
+	if policy.enabled() {
+		attempts = policy.MaxAttempts
+	}
+
+	var lastErr error
+	var lastResult requestResult
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return lastResult, err
+		}
+
+		resp, result, err := doOnce(ctx, hc, timeout, method, url, token, payload, out)
+		lastResult = result
+		if err == nil {
+			return result, nil
+		}
+		lastErr = err
+
+		if attempt == attempts || !shouldRetry(method, resp, err) {
+			return result, err
+		}
+
+		delay := policy.backoff(attempt)
+		if result.RetryAfter > 0 {
+			delay = result.RetryAfter
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return lastResult, ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return lastResult, lastErr
+}
+
+// doOnce performs a single HTTP attempt. It returns the response (so the
+// caller can inspect status/headers for retry decisions) alongside any
+// error; resp is non-nil only when the round trip itself succeeded.
+func doOnce(ctx context.Context, hc *http.Client, timeout time.Duration, method, url, token string, payload []byte, out interface{}) (*http.Response, requestResult, error) {
+	reqCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		reqCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var bodyReader io.Reader
+	if payload != nil {
+		bodyReader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(reqCtx, method, url, bodyReader)
+	if err != nil {
+		return nil, requestResult{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("x-sms-ir-secure-token", token)
+	}
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, requestResult{}, ctx.Err()
+		}
+		return nil, requestResult{}, err
+	}
+	defer resp.Body.Close()
+
+	result := requestResult{StatusCode: resp.StatusCode}
+	if ra, ok := retryAfter(resp.Header); ok {
+		result.RetryAfter = ra
+	}
+
+	if shouldRetry(method, resp, nil) {
+		return resp, result, statusError(method, url, result)
+	}
+
+	if out == nil {
+		return resp, result, nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return resp, result, fmt.Errorf("sms: decode response: %w", err)
+	}
+	return resp, result, nil
+}
@@ -0,0 +1,10 @@
+package sms
+
+import "strconv"
+
+// formatNumericId renders an id field SMS.ir returns as a JSON number
+// (VerificationCodeId, MessageId, ...) into the plain decimal string
+// callers expect.
+func formatNumericId(id float64) string {
+	return strconv.FormatFloat(id, 'f', -1, 64)
+}
@@ -0,0 +1,41 @@
+package webhook
+
+import (
+	"sync"
+	"time"
+)
+
+// nonceCache remembers nonces seen within the replay window, so a captured
+// request can't be replayed after the fact. Entries older than the window
+// are swept on every check call instead of on a timer, since webhook
+// traffic is bursty and rarely idle long enough for a ticker to matter.
+type nonceCache struct {
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// check records nonce at observedAt and reports whether it's a replay
+// (already recorded within window) or outside the allowed clock skew.
+func (c *nonceCache) check(nonce string, observedAt, now time.Time, window time.Duration) error {
+	if now.Sub(observedAt) > window || observedAt.Sub(now) > window {
+		return errClockSkew
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.seen == nil {
+		c.seen = map[string]time.Time{}
+	}
+	for n, at := range c.seen {
+		if now.Sub(at) > window {
+			delete(c.seen, n)
+		}
+	}
+
+	if _, ok := c.seen[nonce]; ok {
+		return errReplayed
+	}
+	c.seen[nonce] = observedAt
+	return nil
+}
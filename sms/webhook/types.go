@@ -0,0 +1,35 @@
+package webhook
+
+// DeliveryReport is a delivery-status callback SMS.ir posts when a
+// previously sent message's status changes (e.g. delivered, failed).
+type DeliveryReport struct {
+	MessageId int64  `json:"MessageId"`
+	Status    int    `json:"Status"`
+	Mobile    string `json:"Mobile"`
+}
+
+// InboundMessage is an SMS a mobile number sent to one of the account's
+// line numbers, which SMS.ir posts to the webhook in near real time.
+type InboundMessage struct {
+	MessageId   int64  `json:"MessageId"`
+	Mobile      string `json:"Mobile"`
+	Text        string `json:"Text"`
+	LineNumber  string `json:"LineNumber"`
+	ReceiveDate string `json:"ReceiveDate"`
+}
+
+// payload is the envelope SMS.ir wraps every webhook callback in. Exactly
+// one of Delivery/Inbound is set, selected by Type. Nonce and Timestamp are
+// required on every payload and enforce the replay window.
+type payload struct {
+	Type      string          `json:"Type"`
+	Nonce     string          `json:"Nonce"`
+	Timestamp int64           `json:"Timestamp"`
+	Delivery  *DeliveryReport `json:"Delivery,omitempty"`
+	Inbound   *InboundMessage `json:"Inbound,omitempty"`
+}
+
+const (
+	typeDelivery = "DeliveryReport"
+	typeInbound  = "InboundMessage"
+)
@@ -0,0 +1,134 @@
+// Package webhook receives the delivery-status and inbound-SMS callbacks
+// SMS.ir posts to a customer URL, verifies them, and dispatches them to
+// caller-supplied handlers.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultSignatureHeader = "X-Sms-Ir-Signature"
+	defaultReplayWindow    = 5 * time.Minute
+)
+
+var (
+	errClockSkew = errors.New("webhook: timestamp outside replay window")
+	errReplayed  = errors.New("webhook: nonce already seen")
+	errNoNonce   = errors.New("webhook: missing nonce or timestamp")
+)
+
+// Options configures a webhook Handler.
+type Options struct {
+	// Secret is the shared secret SMS.ir signs requests with. If empty,
+	// signature verification is skipped.
+	Secret string
+	// SignatureHeader names the header carrying the hex-encoded
+	// HMAC-SHA256 signature of the raw request body. Defaults to
+	// "X-Sms-Ir-Signature".
+	SignatureHeader string
+	// ReplayWindow bounds how far a payload's Timestamp may drift from
+	// now, and how long its Nonce is remembered to reject replays.
+	// Defaults to 5 minutes.
+	ReplayWindow time.Duration
+
+	// OnDelivery, if set, is called for every delivery-status callback.
+	OnDelivery func(DeliveryReport)
+	// OnInbound, if set, is called for every inbound-message callback.
+	OnInbound func(InboundMessage)
+}
+
+func (o Options) signatureHeader() string {
+	if o.SignatureHeader != "" {
+		return o.SignatureHeader
+	}
+	return defaultSignatureHeader
+}
+
+func (o Options) replayWindow() time.Duration {
+	if o.ReplayWindow > 0 {
+		return o.ReplayWindow
+	}
+	return defaultReplayWindow
+}
+
+// handler is the http.Handler NewHandler returns.
+type handler struct {
+	opts  Options
+	nonce nonceCache
+	now   func() time.Time
+}
+
+// NewHandler returns an http.Handler that verifies and dispatches SMS.ir
+// webhook callbacks according to opts.
+func NewHandler(opts Options) http.Handler {
+	return &handler{opts: opts, now: time.Now}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "webhook: read body", http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	if h.opts.Secret != "" {
+		if !h.validSignature(r.Header.Get(h.opts.signatureHeader()), body) {
+			http.Error(w, "webhook: invalid signature", http.StatusUnauthorized)
+			return
+		}
+	}
+
+	var p payload
+	if err := json.Unmarshal(body, &p); err != nil {
+		http.Error(w, "webhook: malformed JSON", http.StatusBadRequest)
+		return
+	}
+
+	if p.Nonce == "" || p.Timestamp == 0 {
+		http.Error(w, errNoNonce.Error(), http.StatusBadRequest)
+		return
+	}
+	observedAt := time.Unix(p.Timestamp, 0)
+	if err := h.nonce.check(p.Nonce, observedAt, h.now(), h.opts.replayWindow()); err != nil {
+		status := http.StatusConflict
+		if errors.Is(err, errClockSkew) {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	switch p.Type {
+	case typeDelivery:
+		if p.Delivery != nil && h.opts.OnDelivery != nil {
+			h.opts.OnDelivery(*p.Delivery)
+		}
+	case typeInbound:
+		if p.Inbound != nil && h.opts.OnInbound != nil {
+			h.opts.OnInbound(*p.Inbound)
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *handler) validSignature(header string, body []byte) bool {
+	sig, err := hex.DecodeString(header)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(h.opts.Secret))
+	mac.Write(body)
+	expected := mac.Sum(nil)
+	return subtle.ConstantTimeCompare(sig, expected) == 1
+}
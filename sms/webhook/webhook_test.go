@@ -0,0 +1,174 @@
+package webhook_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amirhosseinab/go-sms-ir/sms/webhook"
+)
+
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func nowUnix() string {
+	return strconv.FormatInt(time.Now().Unix(), 10)
+}
+
+func TestHandlerDispatchesDeliveryReport(t *testing.T) {
+	var got webhook.DeliveryReport
+	h := webhook.NewHandler(webhook.Options{
+		OnDelivery: func(r webhook.DeliveryReport) { got = r },
+	})
+
+	body := []byte(`{"Type":"DeliveryReport","Nonce":"n1","Timestamp":` + nowUnix() + `,"Delivery":{"MessageId":1,"Status":2,"Mobile":"0912"}}`)
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(string(body)))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if got.MessageId != 1 || got.Status != 2 || got.Mobile != "0912" {
+		t.Errorf("unexpected delivery report: %+v", got)
+	}
+}
+
+func TestHandlerDispatchesInboundMessage(t *testing.T) {
+	var got webhook.InboundMessage
+	h := webhook.NewHandler(webhook.Options{
+		OnInbound: func(m webhook.InboundMessage) { got = m },
+	})
+
+	body := []byte(`{"Type":"InboundMessage","Nonce":"n2","Timestamp":` + nowUnix() + `,"Inbound":{"MessageId":1,"Mobile":"0912","Text":"hi","LineNumber":"3000"}}`)
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(string(body)))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if got.MessageId != 1 || got.Mobile != "0912" || got.Text != "hi" || got.LineNumber != "3000" {
+		t.Errorf("unexpected inbound message: %+v", got)
+	}
+}
+
+func TestHandlerRejectsMalformedJSON(t *testing.T) {
+	h := webhook.NewHandler(webhook.Options{})
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("not json"))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", rec.Code)
+	}
+}
+
+func TestHandlerSignatureVerification(t *testing.T) {
+	secret := "shared-secret"
+	body := []byte(`{"Type":"DeliveryReport","Nonce":"sig-1","Timestamp":` + nowUnix() + `,"Delivery":{"MessageId":1,"Status":2}}`)
+
+	td := []struct {
+		name       string
+		signature  string
+		wantStatus int
+	}{
+		{name: "valid signature", signature: sign(secret, body), wantStatus: http.StatusOK},
+		{name: "wrong signature", signature: sign("other-secret", body), wantStatus: http.StatusUnauthorized},
+		{name: "missing signature", signature: "", wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, d := range td {
+		t.Run(d.name, func(t *testing.T) {
+			h := webhook.NewHandler(webhook.Options{Secret: secret})
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(string(body)))
+			if d.signature != "" {
+				req.Header.Set("X-Sms-Ir-Signature", d.signature)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != d.wantStatus {
+				t.Errorf("expected status %d, got %d", d.wantStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestHandlerUsesConfiguredSignatureHeader(t *testing.T) {
+	secret := "shared-secret"
+	body := []byte(`{"Type":"DeliveryReport","Nonce":"sig-2","Timestamp":` + nowUnix() + `,"Delivery":{"MessageId":1,"Status":2}}`)
+	h := webhook.NewHandler(webhook.Options{Secret: secret, SignatureHeader: "X-Custom-Signature"})
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(string(body)))
+	req.Header.Set("X-Custom-Signature", sign(secret, body))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", rec.Code)
+	}
+}
+
+func TestHandlerRejectsReplayedNonce(t *testing.T) {
+	h := webhook.NewHandler(webhook.Options{})
+
+	payload := struct {
+		Type      string `json:"Type"`
+		Nonce     string `json:"Nonce"`
+		Timestamp int64  `json:"Timestamp"`
+	}{Type: "DeliveryReport", Nonce: "abc", Timestamp: time.Now().Unix()}
+	body, _ := json.Marshal(payload)
+
+	rec1 := httptest.NewRecorder()
+	h.ServeHTTP(rec1, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(string(body))))
+	if rec1.Code != http.StatusOK {
+		t.Fatalf("expected first delivery to succeed, got %d", rec1.Code)
+	}
+
+	rec2 := httptest.NewRecorder()
+	h.ServeHTTP(rec2, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(string(body))))
+	if rec2.Code != http.StatusConflict {
+		t.Errorf("expected replay to be rejected with 409, got %d", rec2.Code)
+	}
+}
+
+func TestHandlerRejectsClockSkew(t *testing.T) {
+	h := webhook.NewHandler(webhook.Options{ReplayWindow: time.Minute})
+
+	payload := struct {
+		Type      string `json:"Type"`
+		Nonce     string `json:"Nonce"`
+		Timestamp int64  `json:"Timestamp"`
+	}{Type: "DeliveryReport", Nonce: "old", Timestamp: time.Now().Add(-time.Hour).Unix()}
+	body, _ := json.Marshal(payload)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(string(body))))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected stale timestamp to be rejected with 400, got %d", rec.Code)
+	}
+}
+
+func TestHandlerRejectsMissingNonce(t *testing.T) {
+	h := webhook.NewHandler(webhook.Options{})
+
+	body := []byte(`{"Type":"DeliveryReport","Delivery":{"MessageId":1,"Status":2}}`)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(string(body))))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected a payload without Nonce/Timestamp to be rejected with 400, got %d", rec.Code)
+	}
+}